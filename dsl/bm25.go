@@ -0,0 +1,179 @@
+package dsl
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+//BM25Options configures SearchBM25.  The zero value uses the Okapi BM25 defaults (k1=1.2, b=0.75),
+//lowercases tokens, and applies no stopword removal or result cutoff.
+type BM25Options struct {
+	//K1 controls term-frequency saturation.  Defaults to 1.2 when zero.
+	K1 float64
+	//B controls document-length normalization (smaller values weaken it, 1 fully normalizes). Defaults to
+	//0.75 when zero, so normalization cannot be switched off entirely through B.
+	B float64
+	//Stopwords, when set, names a built-in stopword preset to strip before scoring ("english" is
+	//currently the only preset).  Empty disables stopword removal.
+	Stopwords string
+	//MinScore drops results scoring at or below this value.  Zero keeps everything that matches at least one term.
+	MinScore float64
+	//Limit caps the number of results returned.  Zero means unlimited.
+	Limit int
+}
+
+func (opts BM25Options) k1() float64 {
+	if opts.K1 == 0 {
+		return 1.2
+	}
+	return opts.K1
+}
+
+func (opts BM25Options) b() float64 {
+	if opts.B == 0 {
+		return 0.75
+	}
+	return opts.B
+}
+
+var englishStopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "has": true, "he": true, "in": true, "is": true,
+	"it": true, "its": true, "of": true, "on": true, "that": true, "the": true, "to": true,
+	"was": true, "were": true, "will": true, "with": true,
+}
+
+func tokenize(text string, opts BM25Options) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+
+	// Only the literal "english" preset strips anything; any other value (including a typo'd or
+	// future preset name) leaves tokens untouched rather than silently applying English stopwords.
+	if opts.Stopwords != "english" {
+		return fields
+	}
+
+	tokens := fields[:0]
+	for _, field := range fields {
+		if !englishStopwords[field] {
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
+
+//RankedEntry pairs an Entry with the BM25 Score it received for a query.
+type RankedEntry struct {
+	Entry Entry
+	Score float64
+}
+
+//RankedEntries is a slice of RankedEntry sorted in descending order of Score.
+type RankedEntries []RankedEntry
+
+type posting struct {
+	doc int
+	tf  int
+}
+
+type bm25Index struct {
+	postings  map[string][]posting
+	docLen    []int
+	avgDocLen float64
+}
+
+func buildBM25Index(entries Entries, opts BM25Options) bm25Index {
+	index := bm25Index{
+		postings: map[string][]posting{},
+		docLen:   make([]int, len(entries)),
+	}
+
+	totalLen := 0
+	for doc, entry := range entries {
+		termFreq := map[string]int{}
+		tokens := tokenize(entry.Message, opts)
+		for _, token := range tokens {
+			termFreq[token]++
+		}
+		index.docLen[doc] = len(tokens)
+		totalLen += len(tokens)
+
+		for term, tf := range termFreq {
+			index.postings[term] = append(index.postings[term], posting{doc: doc, tf: tf})
+		}
+	}
+
+	if len(entries) > 0 {
+		index.avgDocLen = float64(totalLen) / float64(len(entries))
+	}
+	return index
+}
+
+//SearchBM25 ranks e by BM25 relevance to query and returns matches sorted by descending score.
+//
+//A per-corpus inverted index (token -> postings of (entry index, term frequency)) is built once,
+//then for each query term its postings are fetched and merged into a per-entry running score - the
+//same score-merger approach used to combine per-term posting lists in full-text search engines.
+//Entries matching none of the query terms are never scored and so never appear in the result.
+func (e Entries) SearchBM25(query string, opts BM25Options) RankedEntries {
+	index := buildBM25Index(e, opts)
+	queryTerms := tokenize(query, opts)
+
+	k1, b := opts.k1(), opts.b()
+	n := float64(len(e))
+
+	scores := map[int]float64{}
+	for _, term := range queryTerms {
+		postings, ok := index.postings[term]
+		if !ok {
+			continue
+		}
+
+		idf := math.Log(1 + (n-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+
+		for _, p := range postings {
+			tf := float64(p.tf)
+			docLen := float64(index.docLen[p.doc])
+			denom := tf + k1*(1-b+b*docLen/index.avgDocLen)
+			scores[p.doc] += idf * tf * (k1 + 1) / denom
+		}
+	}
+
+	ranked := make(RankedEntries, 0, len(scores))
+	for doc, score := range scores {
+		if score <= opts.MinScore {
+			continue
+		}
+		ranked = append(ranked, RankedEntry{Entry: e[doc], Score: score})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	if opts.Limit > 0 && len(ranked) > opts.Limit {
+		ranked = ranked[:opts.Limit]
+	}
+	return ranked
+}
+
+//SearchBM25 ranks every group's Entries independently against query, via Entries.SearchBM25, and
+//returns the combined RankedEntries sorted by descending score across all groups.
+func (g *GroupedEntries) SearchBM25(query string, opts BM25Options) RankedEntries {
+	var all RankedEntries
+	g.EachGroup(func(key interface{}, entries Entries) error {
+		all = append(all, entries.SearchBM25(query, opts)...)
+		return nil
+	})
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Score > all[j].Score
+	})
+
+	if opts.Limit > 0 && len(all) > opts.Limit {
+		all = all[:opts.Limit]
+	}
+	return all
+}
@@ -0,0 +1,84 @@
+package dsl
+
+import (
+	"testing"
+	"time"
+)
+
+func bm25Fixture() Entries {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return Entries{
+		{Timestamp: base, Message: "starting auctioneer bid process for task"},
+		{Timestamp: base.Add(time.Second), Message: "auctioneer auctioneer selected cell for task"},
+		{Timestamp: base.Add(2 * time.Second), Message: "rep accepted task and began download"},
+		{Timestamp: base.Add(3 * time.Second), Message: "download complete, running container"},
+	}
+}
+
+func TestSearchBM25RanksByRelevance(t *testing.T) {
+	ranked := bm25Fixture().SearchBM25("auctioneer task", BM25Options{})
+
+	if len(ranked) == 0 {
+		t.Fatalf("expected at least one match")
+	}
+	if ranked[0].Entry.Message != "auctioneer auctioneer selected cell for task" {
+		t.Errorf("expected the entry with the most term occurrences to rank first, got %q", ranked[0].Entry.Message)
+	}
+	for i := 1; i < len(ranked); i++ {
+		if ranked[i].Score > ranked[i-1].Score {
+			t.Errorf("results not sorted descending by score at index %d", i)
+		}
+	}
+}
+
+func TestSearchBM25ExcludesNonMatches(t *testing.T) {
+	ranked := bm25Fixture().SearchBM25("auctioneer", BM25Options{})
+	for _, r := range ranked {
+		if r.Entry.Message == "download complete, running container" {
+			t.Errorf("entry with no query terms should not be scored, got %+v", r)
+		}
+	}
+}
+
+func TestSearchBM25LimitAndMinScore(t *testing.T) {
+	ranked := bm25Fixture().SearchBM25("task", BM25Options{Limit: 1})
+	if len(ranked) != 1 {
+		t.Fatalf("expected Limit to cap results to 1, got %d", len(ranked))
+	}
+
+	none := bm25Fixture().SearchBM25("task", BM25Options{MinScore: 1000})
+	if len(none) != 0 {
+		t.Fatalf("expected MinScore cutoff to drop all results, got %d", len(none))
+	}
+}
+
+func TestSearchBM25StopwordsAreIgnoredWhenConfigured(t *testing.T) {
+	entries := Entries{
+		{Timestamp: time.Now(), Message: "the rep is the process"},
+	}
+	withStopwords := entries.SearchBM25("the", BM25Options{Stopwords: "english"})
+	if len(withStopwords) != 0 {
+		t.Errorf("expected stopword 'the' to be stripped from the query, got %+v", withStopwords)
+	}
+}
+
+func TestSearchBM25UnknownStopwordsPresetLeavesTokensUntouched(t *testing.T) {
+	entries := Entries{
+		{Timestamp: time.Now(), Message: "the rep is the process"},
+	}
+	withTypoPreset := entries.SearchBM25("the", BM25Options{Stopwords: "spanish"})
+	if len(withTypoPreset) == 0 {
+		t.Errorf("expected an unrecognized Stopwords preset to leave 'the' unstripped and still match, got no results")
+	}
+}
+
+func TestGroupedEntriesSearchBM25CombinesGroups(t *testing.T) {
+	g := NewGroupedEntries()
+	g.AppendEntries("auctioneer", bm25Fixture()[:2])
+	g.AppendEntries("rep", bm25Fixture()[2:])
+
+	ranked := g.SearchBM25("task", BM25Options{})
+	if len(ranked) == 0 {
+		t.Fatalf("expected matches across both groups")
+	}
+}
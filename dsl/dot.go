@@ -0,0 +1,173 @@
+package dsl
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+)
+
+//DOTOptions configures the Graphviz DOT output produced by WriteDOTTo.
+//
+//ClusterLabel, when set, is used to generate the label for the cluster associated with a Key.
+//When nil, fmt.Sprintf("%v", key) is used instead.
+//
+//NodeShape, when set, picks the node shape for an Entry based on its LogLevel (e.g. "box" for
+//error-level entries, "ellipse" otherwise).  When nil, all nodes are rendered as "ellipse".
+//
+//NodeColor, when set, picks the DOT color for an Entry's node.  When nil, nodes are colored by
+//Session if the Entry has one (a distinct, stable color per session/task, via hashNodeColor) and
+//fall back to logLevelColor otherwise - so causally related entries from the same session/task
+//stand out as the same color even across cluster boundaries.
+//
+//CorrelationKey extracts a correlation attribute (e.g. a request-id or trace-id) from an Entry's
+//Data.  Entries across different groups that return the same (non-empty) correlation key are
+//connected with a cross-group edge.  When nil, no cross-group edges are emitted.
+//
+//WeightEdges, when true, sets the DOT "weight" attribute on sequential edges within a group to the
+//number of seconds elapsed between the two entries (rounded up to 1), so that `dot` can use the
+//time delta to influence layout.
+type DOTOptions struct {
+	ClusterLabel   func(key interface{}) string
+	NodeShape      func(level LogLevel) string
+	NodeColor      func(Entry) string
+	CorrelationKey func(Entry) (string, bool)
+	WeightEdges    bool
+}
+
+func (opts DOTOptions) clusterLabel(key interface{}) string {
+	if opts.ClusterLabel != nil {
+		return opts.ClusterLabel(key)
+	}
+	return fmt.Sprintf("%v", key)
+}
+
+func (opts DOTOptions) nodeShape(level LogLevel) string {
+	if opts.NodeShape != nil {
+		return opts.NodeShape(level)
+	}
+	return "ellipse"
+}
+
+func (opts DOTOptions) nodeColor(entry Entry) string {
+	if opts.NodeColor != nil {
+		return opts.NodeColor(entry)
+	}
+	if entry.Session != "" {
+		return hashNodeColor(entry.Session)
+	}
+	return logLevelColor(entry.LogLevel)
+}
+
+//WriteDOTTo emits a Graphviz DOT graph of the GroupedEntries to w.
+//
+//Each Key becomes a subgraph cluster, each Entry in that Key's Entries becomes a node labeled with
+//its timestamp and colored per opts.nodeColor (by session/task by default), and entries within a
+//group are connected in sequence by directed edges.  If opts.CorrelationKey is provided, additional
+//cross-group edges are drawn wherever two entries (in different clusters) share a correlation value
+//- this surfaces causal relationships (e.g. a shared request-id) that the per-group ordering alone
+//can't show.
+func (g *GroupedEntries) WriteDOTTo(w io.Writer, opts DOTOptions) error {
+	fmt.Fprintf(w, "digraph GroupedEntries {\n")
+
+	type correlated struct {
+		nodeID  string
+		cluster int
+		entry   Entry
+	}
+	correlations := map[string][]correlated{}
+
+	for i, key := range g.Keys {
+		fmt.Fprintf(w, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(w, "    label=%q;\n", opts.clusterLabel(key))
+
+		entries := g.Entries[i]
+		var prevID string
+		for j, entry := range entries {
+			nodeID := fmt.Sprintf("n%d_%d", i, j)
+			fmt.Fprintf(w, "    %s [label=%q, shape=%q, color=%q];\n",
+				nodeID, fmt.Sprintf("%s\\n%s", entry.Timestamp.Format("15:04:05.000"), entry.Message),
+				opts.nodeShape(entry.LogLevel), opts.nodeColor(entry))
+
+			if prevID != "" {
+				if opts.WeightEdges {
+					weight := int(entries[j].Timestamp.Sub(entries[j-1].Timestamp).Seconds())
+					if weight < 1 {
+						weight = 1
+					}
+					fmt.Fprintf(w, "    %s -> %s [weight=%d];\n", prevID, nodeID, weight)
+				} else {
+					fmt.Fprintf(w, "    %s -> %s;\n", prevID, nodeID)
+				}
+			}
+			prevID = nodeID
+
+			if opts.CorrelationKey != nil {
+				if key, ok := opts.CorrelationKey(entry); ok && key != "" {
+					correlations[key] = append(correlations[key], correlated{nodeID, i, entry})
+				}
+			}
+		}
+		fmt.Fprintf(w, "  }\n")
+	}
+
+	if opts.CorrelationKey != nil {
+		keys := make([]string, 0, len(correlations))
+		for key := range correlations {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			nodes := correlations[key]
+			for i := 1; i < len(nodes); i++ {
+				// entries in the same cluster are already linked by the sequential edges
+				if nodes[i-1].cluster == nodes[i].cluster {
+					continue
+				}
+				fmt.Fprintf(w, "  %s -> %s [style=dashed, constraint=false, label=%q];\n",
+					nodes[i-1].nodeID, nodes[i].nodeID, key)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "}\n")
+	return nil
+}
+
+//WriteDOTTo emits a Graphviz DOT graph of the Timelines to w.
+//
+//Each Timeline becomes a subgraph cluster labeled with its Annotation, each TimelineEntry becomes a
+//node timestamped and colored per opts.nodeColor, and sequential entries within a Timeline are
+//connected by directed edges.  Cross-Timeline edges follow the same opts.CorrelationKey rule as
+//GroupedEntries.WriteDOTTo.
+func (t Timelines) WriteDOTTo(w io.Writer, opts DOTOptions) error {
+	grouped := NewGroupedEntries()
+	for _, timeline := range t {
+		grouped.AppendEntries(timeline.Annotation, timeline.Entries())
+	}
+	return grouped.WriteDOTTo(w, opts)
+}
+
+//hashNodeColor derives a stable DOT color from an arbitrary string (a Session or task identifier)
+//by hashing it to a hue and expressing it in DOT's "H,S,V" color format, so the same session/task
+//always gets the same color without maintaining an explicit id->color table.
+func hashNodeColor(s string) string {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	hue := float64(h.Sum32()%360) / 360
+	return fmt.Sprintf("%.3f,0.65,0.85", hue)
+}
+
+func logLevelColor(level LogLevel) string {
+	switch level {
+	case LogLevelError, LogLevelFatal:
+		return "red"
+	case LogLevelInfo:
+		return "black"
+	case LogLevelDebug:
+		return "gray"
+	default:
+		return "black"
+	}
+}
@@ -0,0 +1,72 @@
+package dsl
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func dotFixture() *GroupedEntries {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	g := NewGroupedEntries()
+	g.AppendEntries("auctioneer", Entries{
+		{Timestamp: base, Message: "starting bid", Data: map[string]interface{}{"request-id": "abc"}},
+		{Timestamp: base.Add(time.Second), Message: "selected cell"},
+	})
+	g.AppendEntries("rep", Entries{
+		{Timestamp: base.Add(2 * time.Second), Message: "accepted task", Data: map[string]interface{}{"request-id": "abc"}},
+	})
+	return g
+}
+
+func requestIDCorrelation(e Entry) (string, bool) {
+	id, ok := e.Data["request-id"].(string)
+	return id, ok
+}
+
+func TestWriteDOTToEmitsOneClusterPerKey(t *testing.T) {
+	var buf strings.Builder
+	if err := dotFixture().WriteDOTTo(&buf, DOTOptions{}); err != nil {
+		t.Fatalf("WriteDOTTo returned error: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Count(out, "subgraph cluster_") != 2 {
+		t.Errorf("expected one cluster per Key (2 keys), got output:\n%s", out)
+	}
+}
+
+func TestWriteDOTToConnectsSequentialEntriesWithinAGroup(t *testing.T) {
+	var buf strings.Builder
+	if err := dotFixture().WriteDOTTo(&buf, DOTOptions{}); err != nil {
+		t.Fatalf("WriteDOTTo returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "n0_0 -> n0_1;") {
+		t.Errorf("expected a sequential edge between the two auctioneer entries, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteDOTToOmitsCrossGroupEdgesWithoutCorrelationKey(t *testing.T) {
+	var buf strings.Builder
+	if err := dotFixture().WriteDOTTo(&buf, DOTOptions{}); err != nil {
+		t.Fatalf("WriteDOTTo returned error: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "constraint=false") {
+		t.Errorf("expected no cross-group edges when CorrelationKey is nil, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteDOTToAddsCrossGroupEdgeWhenCorrelationKeyMatches(t *testing.T) {
+	var buf strings.Builder
+	opts := DOTOptions{CorrelationKey: requestIDCorrelation}
+	if err := dotFixture().WriteDOTTo(&buf, opts); err != nil {
+		t.Fatalf("WriteDOTTo returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "n0_0 -> n1_0") || !strings.Contains(buf.String(), "constraint=false") {
+		t.Errorf("expected a cross-group edge between the two request-id=abc entries, got:\n%s", buf.String())
+	}
+}
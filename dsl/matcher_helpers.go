@@ -0,0 +1,12 @@
+package dsl
+
+import "strings"
+
+//MatchMessage returns a Matcher that accepts any Entry whose Message contains substring
+//(case-sensitive).  It's the matcher constructor used for ad-hoc, user-typed filters - e.g. the
+//tui package's live filter prompt - where a full query language would be overkill.
+func MatchMessage(substring string) Matcher {
+	return func(e Entry) bool {
+		return strings.Contains(e.Message, substring)
+	}
+}
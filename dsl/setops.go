@@ -0,0 +1,237 @@
+package dsl
+
+import (
+	"sort"
+)
+
+//entryKey returns a comparable identity for an Entry, used to test set membership across two
+//Entries lists.  Entries within a single Key are already time-ordered (see sort.Sort usage
+//elsewhere in this package), so pairing the timestamp with the message is enough to recognize "the
+//same log line" without requiring Entry to expose a dedicated unique ID.
+type entryKey struct {
+	nanos   int64
+	message string
+}
+
+func keyOf(e Entry) entryKey {
+	return entryKey{nanos: e.Timestamp.UnixNano(), message: e.Message}
+}
+
+//sortedCopy returns a copy of e ordered by the same (timestamp, message) comparator keyOf/less use
+//for set membership.  Sorting via Entries' own (timestamp-only) Less instead would leave entries
+//that share a timestamp - common for batched log writes - in whatever order sort.Sort's unstable
+//partitioning happens to produce, which can differ between the two operands being merged and would
+//silently drop or duplicate entries in the merge routines below.
+func sortedCopy(e Entries) Entries {
+	out := make(Entries, len(e))
+	copy(out, e)
+	sort.Slice(out, func(i, j int) bool {
+		return less(keyOf(out[i]), keyOf(out[j]))
+	})
+	return out
+}
+
+//Intersect returns a new GroupedEntries containing, for every Key present in both g and other, the
+//Entries that appear in both operands' lists for that Key.  Keys present in only one operand are
+//dropped entirely.
+func (g *GroupedEntries) Intersect(other *GroupedEntries) *GroupedEntries {
+	return g.combine(other, false, func(a, b Entries) Entries {
+		return intersectSorted(sortedCopy(a), sortedCopy(b))
+	})
+}
+
+//Union returns a new GroupedEntries containing, for every Key present in either g or other, the
+//Entries that appear in at least one operand's list for that Key.
+func (g *GroupedEntries) Union(other *GroupedEntries) *GroupedEntries {
+	return g.combine(other, true, func(a, b Entries) Entries {
+		return unionSorted(sortedCopy(a), sortedCopy(b))
+	})
+}
+
+//Difference returns a new GroupedEntries containing, for every Key in g, the Entries present in g's
+//list for that Key but absent from other's list for the same Key.  Keys present only in other are dropped.
+func (g *GroupedEntries) Difference(other *GroupedEntries) *GroupedEntries {
+	result := NewGroupedEntries()
+	g.EachGroup(func(key interface{}, entries Entries) error {
+		otherEntries, _ := other.Lookup(key)
+		diff := differenceSorted(sortedCopy(entries), sortedCopy(otherEntries))
+		if len(diff) > 0 {
+			result.AppendEntries(key, diff)
+		}
+		return nil
+	})
+	return result
+}
+
+//combine merges g and other key-by-key using merge, which is handed both operands' Entries
+//(possibly empty, never both empty) for a Key.  includeUnmatched controls whether Keys present in
+//only one operand are still visited (true for Union, false for Intersect).
+func (g *GroupedEntries) combine(other *GroupedEntries, includeUnmatched bool, merge func(a, b Entries) Entries) *GroupedEntries {
+	result := NewGroupedEntries()
+	seen := map[interface{}]bool{}
+
+	g.EachGroup(func(key interface{}, entries Entries) error {
+		seen[key] = true
+		otherEntries, hasOther := other.Lookup(key)
+		if !hasOther && !includeUnmatched {
+			return nil
+		}
+		merged := merge(entries, otherEntries)
+		if len(merged) > 0 {
+			result.AppendEntries(key, merged)
+		}
+		return nil
+	})
+
+	if includeUnmatched {
+		other.EachGroup(func(key interface{}, entries Entries) error {
+			if seen[key] {
+				return nil
+			}
+			merged := merge(nil, entries)
+			if len(merged) > 0 {
+				result.AppendEntries(key, merged)
+			}
+			return nil
+		})
+	}
+
+	return result
+}
+
+//gallopThreshold is the size-ratio at or above which intersectSorted switches from a linear merge to the
+//adaptive linear-jump/gallop strategy.  Below it, a plain two-pointer merge is already optimal.
+const gallopThreshold = 8
+
+//intersectSorted computes the sorted intersection of two sorted (by entryKey) Entries lists.  When
+//one list is much longer than the other (ratio >= gallopThreshold) it gallops through the longer
+//list for each element of the shorter one: starting with a linear scan and exponentially doubling
+//the stride as the gap widens, then binary-searching within the last bracket once the stride
+//overshoots.  This keeps the common case - a small "probe" list intersected against a huge log
+//stream - close to O(short * log(long)) instead of O(short + long).
+func intersectSorted(a, b Entries) Entries {
+	if len(a) == 0 || len(b) == 0 {
+		return Entries{}
+	}
+
+	// gallop the shorter list through the longer one
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	if len(b)/max(len(a), 1) >= gallopThreshold {
+		return gallopIntersect(a, b)
+	}
+	return linearIntersect(a, b)
+}
+
+func linearIntersect(a, b Entries) Entries {
+	out := Entries{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ak, bk := keyOf(a[i]), keyOf(b[j])
+		switch {
+		case ak == bk:
+			out = append(out, a[i])
+			i++
+			j++
+		case less(ak, bk):
+			i++
+		default:
+			j++
+		}
+	}
+	return out
+}
+
+//gallopIntersect intersects the short list a against the long list b by, for each element of a,
+//jumping ahead in b with exponentially increasing strides until overshooting the target, then
+//binary-searching the bracket that was jumped over.
+func gallopIntersect(a, b Entries) Entries {
+	out := Entries{}
+	pos := 0
+
+	for _, entry := range a {
+		target := keyOf(entry)
+
+		stride := 1
+		lo := pos
+		hi := pos
+		for hi < len(b) && less(keyOf(b[hi]), target) {
+			lo = hi
+			hi += stride
+			stride *= 2
+		}
+		if hi > len(b) {
+			hi = len(b)
+		}
+
+		idx := sort.Search(hi-lo, func(k int) bool {
+			return !less(keyOf(b[lo+k]), target)
+		}) + lo
+
+		if idx < len(b) && keyOf(b[idx]) == target {
+			out = append(out, entry)
+			pos = idx + 1
+		} else {
+			pos = idx
+		}
+	}
+	return out
+}
+
+func unionSorted(a, b Entries) Entries {
+	out := Entries{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ak, bk := keyOf(a[i]), keyOf(b[j])
+		switch {
+		case ak == bk:
+			out = append(out, a[i])
+			i++
+			j++
+		case less(ak, bk):
+			out = append(out, a[i])
+			i++
+		default:
+			out = append(out, b[j])
+			j++
+		}
+	}
+	out = append(out, a[i:]...)
+	out = append(out, b[j:]...)
+	return out
+}
+
+func differenceSorted(a, b Entries) Entries {
+	out := Entries{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ak, bk := keyOf(a[i]), keyOf(b[j])
+		switch {
+		case ak == bk:
+			i++
+			j++
+		case less(ak, bk):
+			out = append(out, a[i])
+			i++
+		default:
+			j++
+		}
+	}
+	out = append(out, a[i:]...)
+	return out
+}
+
+func less(a, b entryKey) bool {
+	if a.nanos != b.nanos {
+		return a.nanos < b.nanos
+	}
+	return a.message < b.message
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
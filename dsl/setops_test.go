@@ -0,0 +1,116 @@
+package dsl
+
+import (
+	"testing"
+	"time"
+)
+
+func entryAt(t time.Time, message string) Entry {
+	return Entry{Timestamp: t, Message: message}
+}
+
+func TestIntersectKeepsOnlySharedEntriesPerKey(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	a := NewGroupedEntries()
+	a.AppendEntries("task-1", Entries{
+		entryAt(base, "a"),
+		entryAt(base.Add(time.Second), "shared"),
+	})
+	a.AppendEntries("task-2", Entries{entryAt(base, "only in a")})
+
+	b := NewGroupedEntries()
+	b.AppendEntries("task-1", Entries{
+		entryAt(base.Add(time.Second), "shared"),
+		entryAt(base.Add(2*time.Second), "b"),
+	})
+
+	result := a.Intersect(b)
+
+	if _, ok := result.Lookup("task-2"); ok {
+		t.Errorf("task-2 only exists in one operand and should be dropped by Intersect")
+	}
+
+	entries, ok := result.Lookup("task-1")
+	if !ok || len(entries) != 1 || entries[0].Message != "shared" {
+		t.Errorf("expected task-1 intersection to be exactly [shared], got %+v", entries)
+	}
+}
+
+func TestUnionKeepsEntriesFromBothOperands(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	a := NewGroupedEntries()
+	a.AppendEntries("task-1", Entries{entryAt(base, "a")})
+
+	b := NewGroupedEntries()
+	b.AppendEntries("task-1", Entries{entryAt(base.Add(time.Second), "b")})
+	b.AppendEntries("task-2", Entries{entryAt(base, "only in b")})
+
+	result := a.Union(b)
+
+	entries, ok := result.Lookup("task-1")
+	if !ok || len(entries) != 2 {
+		t.Errorf("expected task-1 union to contain both entries, got %+v", entries)
+	}
+	if _, ok := result.Lookup("task-2"); !ok {
+		t.Errorf("expected task-2 (present only in b) to survive Union")
+	}
+}
+
+func TestDifferenceDropsEntriesPresentInOther(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	a := NewGroupedEntries()
+	a.AppendEntries("task-1", Entries{
+		entryAt(base, "keep"),
+		entryAt(base.Add(time.Second), "shared"),
+	})
+
+	b := NewGroupedEntries()
+	b.AppendEntries("task-1", Entries{entryAt(base.Add(time.Second), "shared")})
+	b.AppendEntries("task-2", Entries{entryAt(base, "only in b")})
+
+	result := a.Difference(b)
+
+	entries, ok := result.Lookup("task-1")
+	if !ok || len(entries) != 1 || entries[0].Message != "keep" {
+		t.Errorf("expected task-1 difference to be exactly [keep], got %+v", entries)
+	}
+	if _, ok := result.Lookup("task-2"); ok {
+		t.Errorf("task-2 only exists in other and should not appear in a.Difference(b)")
+	}
+}
+
+func TestIntersectSortedUsesGallopForSkewedSizes(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	short := Entries{
+		entryAt(base.Add(5*time.Second), "needle-1"),
+		entryAt(base.Add(50*time.Second), "needle-2"),
+	}
+
+	long := make(Entries, 0, 100)
+	for i := 0; i < 100; i++ {
+		long = append(long, entryAt(base.Add(time.Duration(i)*time.Second), "haystack"))
+	}
+	long = append(long, short...)
+
+	result := intersectSorted(sortedCopy(short), sortedCopy(long))
+	if len(result) != 2 {
+		t.Fatalf("expected both needles to be found via the gallop path, got %d results: %+v", len(result), result)
+	}
+}
+
+func TestSortedCopyOrdersTiedTimestampsByMessage(t *testing.T) {
+	tie := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := Entries{
+		entryAt(tie, "zebra"),
+		entryAt(tie, "apple"),
+	}
+
+	sorted := sortedCopy(entries)
+	if sorted[0].Message != "apple" || sorted[1].Message != "zebra" {
+		t.Errorf("expected entries sharing a timestamp to be tie-broken by message, got %+v", sorted)
+	}
+}
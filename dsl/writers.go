@@ -0,0 +1,119 @@
+package dsl
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dsnet/compress/bzip2"
+	"github.com/klauspost/compress/zstd"
+)
+
+//WriteLagerFormatToFile writes lager formatted output for all Entries in the group to the file at
+//path, creating or truncating it.  If path ends in ".gz", ".zst", or ".bz2" the output is
+//transparently wrapped in the matching compressor before any bytes are written, so compressed and
+//uncompressed destinations are interchangeable from the caller's perspective.
+func (g *GroupedEntries) WriteLagerFormatToFile(path string) (err error) {
+	f, createErr := os.Create(path)
+	if createErr != nil {
+		return createErr
+	}
+	defer f.Close()
+
+	w, closeWriter, err := wrapCompressed(f, path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := closeWriter(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	err = g.WriteLagerFormatTo(w)
+	return err
+}
+
+//WriteLagerFormatToFile writes lager formatted output for e to the file at path, applying the same
+//suffix-based compression as GroupedEntries.WriteLagerFormatToFile.
+func (e Entries) WriteLagerFormatToFile(path string) (err error) {
+	f, createErr := os.Create(path)
+	if createErr != nil {
+		return createErr
+	}
+	defer f.Close()
+
+	w, closeWriter, err := wrapCompressed(f, path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := closeWriter(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	err = e.WriteLagerFormatTo(w)
+	return err
+}
+
+//wrapCompressed wraps w in a compressor chosen by path's suffix (.gz, .zst, .bz2), or returns w
+//unwrapped for any other suffix.  The returned close func must be called (before the underlying
+//file is closed) to flush any buffered compressed output.
+func wrapCompressed(w io.Writer, path string) (io.Writer, func() error, error) {
+	switch {
+	case strings.HasSuffix(path, ".gz"):
+		gz := gzip.NewWriter(w)
+		return gz, gz.Close, nil
+	case strings.HasSuffix(path, ".zst"):
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, nil, err
+		}
+		return zw, zw.Close, nil
+	case strings.HasSuffix(path, ".bz2"):
+		bw, err := bzip2.NewWriter(w, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		return bw, bw.Close, nil
+	default:
+		return w, func() error { return nil }, nil
+	}
+}
+
+//jsonlEntry is the newline-delimited JSON shape emitted by WriteJSONLTo: one Entry per line, tagged
+//with the Key of the group it came from so a flattened stream can be re-grouped downstream (e.g. by jq).
+type jsonlEntry struct {
+	Key   interface{} `json:"key"`
+	Entry Entry       `json:"entry"`
+}
+
+//WriteJSONLTo emits one JSON object per line (newline-delimited JSON) for every Entry in the group,
+//streamed group-by-group so memory use stays bounded regardless of how large the grouping is - this
+//lets downstream tooling (jq, Logstash/ELK, etc.) consume grouped output without loading it whole.
+func (g *GroupedEntries) WriteJSONLTo(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	return g.EachGroup(func(key interface{}, entries Entries) error {
+		for _, entry := range entries {
+			if err := encoder.Encode(jsonlEntry{Key: key, Entry: entry}); err != nil {
+				return fmt.Errorf("unable to encode entry for key %v: %w", key, err)
+			}
+		}
+		return nil
+	})
+}
+
+//WriteJSONLTo emits one JSON object per line for every Entry in e.
+func (e Entries) WriteJSONLTo(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, entry := range e {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("unable to encode entry: %w", err)
+		}
+	}
+	return nil
+}
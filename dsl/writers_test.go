@@ -0,0 +1,90 @@
+package dsl
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writersFixture() *GroupedEntries {
+	g := NewGroupedEntries()
+	g.AppendEntries("auctioneer", Entries{
+		{Timestamp: time.Now(), Message: "starting bid"},
+		{Timestamp: time.Now(), Message: "selected cell"},
+	})
+	g.AppendEntries("rep", Entries{
+		{Timestamp: time.Now(), Message: "accepted task"},
+	})
+	return g
+}
+
+func TestWriteJSONLToEmitsOneLinePerEntry(t *testing.T) {
+	var buf strings.Builder
+	if err := writersFixture().WriteJSONLTo(&buf); err != nil {
+		t.Fatalf("WriteJSONLTo returned error: %v", err)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(buf.String()))
+	lines := 0
+	for scanner.Scan() {
+		var decoded struct {
+			Key   string `json:"key"`
+			Entry Entry  `json:"entry"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &decoded); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		lines++
+	}
+	if lines != 3 {
+		t.Errorf("expected 3 JSONL lines (one per Entry across both groups), got %d", lines)
+	}
+}
+
+func TestWriteLagerFormatToFilePlain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	if err := writersFixture().WriteLagerFormatToFile(path); err != nil {
+		t.Fatalf("WriteLagerFormatToFile returned error: %v", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading output file: %v", err)
+	}
+	if !strings.Contains(string(contents), "starting bid") {
+		t.Errorf("expected plain output to contain entry text, got %q", contents)
+	}
+}
+
+func TestWriteLagerFormatToFileGzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.gz")
+	if err := writersFixture().WriteLagerFormatToFile(path); err != nil {
+		t.Fatalf("WriteLagerFormatToFile returned error: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening output file: %v", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("output file is not valid gzip: %v", err)
+	}
+	defer gz.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(gz); err != nil {
+		t.Fatalf("reading decompressed output: %v", err)
+	}
+	if !strings.Contains(buf.String(), "accepted task") {
+		t.Errorf("expected decompressed output to contain entry text, got %q", buf.String())
+	}
+}
@@ -0,0 +1,139 @@
+package tui
+
+//Box is a node in a layout tree.  A Box with no Children is a leaf and is assigned a Name so the
+//caller can look up its computed Dimensions after Arrange runs; a Box with Children lays its
+//children out along Direction, splitting the available space between them according to Weight
+//(children with no Weight share the space equally) while respecting each child's MinWidth/MinHeight.
+//
+//This mirrors the boxlayout approach used by terminal dashboards built on gocui: a tree of
+//horizontal/vertical splits recomputed on every resize rather than a grid of fixed coordinates.
+type Box struct {
+	Name      string
+	Weight    int
+	MinWidth  int
+	MinHeight int
+	Direction Direction
+	Children  []*Box
+}
+
+//Direction controls how a Box with Children splits its available space.
+type Direction int
+
+const (
+	//DirectionRow splits children left-to-right.
+	DirectionRow Direction = iota
+	//DirectionColumn splits children top-to-bottom.
+	DirectionColumn
+)
+
+//Dimensions is the computed screen-space rectangle for a leaf Box, in the same coordinate system
+//gocui views use: (X0, Y0) is the top-left corner, (X1, Y1) is the bottom-right corner, exclusive.
+type Dimensions struct {
+	X0, Y0, X1, Y1 int
+}
+
+//Arrange computes the Dimensions of every named leaf Box in the tree rooted at b, given a viewport
+//of width x height, and returns them keyed by Box.Name.  Call this once at startup and again on every
+//resize event so panels reflow instead of being pinned to stale coordinates.
+func (b *Box) Arrange(width, height int) map[string]Dimensions {
+	out := map[string]Dimensions{}
+	b.arrange(Dimensions{0, 0, width, height}, out)
+	return out
+}
+
+func (b *Box) arrange(bounds Dimensions, out map[string]Dimensions) {
+	if len(b.Children) == 0 {
+		if b.Name != "" {
+			out[b.Name] = bounds
+		}
+		return
+	}
+
+	available := bounds.X1 - bounds.X0
+	if b.Direction == DirectionColumn {
+		available = bounds.Y1 - bounds.Y0
+	}
+
+	sizes := b.childSizes(available)
+
+	offset := 0
+	for i, child := range b.Children {
+		size := sizes[i]
+
+		var childBounds Dimensions
+		if b.Direction == DirectionRow {
+			childBounds = Dimensions{bounds.X0 + offset, bounds.Y0, bounds.X0 + offset + size, bounds.Y1}
+		} else {
+			childBounds = Dimensions{bounds.X0, bounds.Y0 + offset, bounds.X1, bounds.Y0 + offset + size}
+		}
+		child.arrange(childBounds, out)
+		offset += size
+	}
+}
+
+//childSizes computes, for each child, a size along Direction that always sums to exactly
+//available.  Children get their MinWidth/MinHeight first; if the minimums alone don't fit in
+//available, they're shrunk proportionally (rather than left to overflow the container) so every
+//rectangle stays non-negative and the accumulated offsets can never run past available.  Any space
+//left over after minimums is distributed by Weight.
+func (b *Box) childSizes(available int) []int {
+	mins := make([]int, len(b.Children))
+	totalMin := 0
+	for i, child := range b.Children {
+		mins[i] = minFor(child, b.Direction)
+		totalMin += mins[i]
+	}
+
+	sizes := make([]int, len(b.Children))
+
+	if totalMin >= available {
+		if totalMin == 0 {
+			return sizes
+		}
+		consumed := 0
+		for i := range b.Children {
+			if i == len(b.Children)-1 {
+				sizes[i] = available - consumed
+			} else {
+				sizes[i] = available * mins[i] / totalMin
+				consumed += sizes[i]
+			}
+			if sizes[i] < 0 {
+				sizes[i] = 0
+			}
+		}
+		return sizes
+	}
+
+	totalWeight := 0
+	for _, child := range b.Children {
+		totalWeight += childWeight(child)
+	}
+
+	remaining := available - totalMin
+	consumed := 0
+	for i, child := range b.Children {
+		if i == len(b.Children)-1 {
+			sizes[i] = available - consumed
+			continue
+		}
+		extra := remaining * childWeight(child) / totalWeight
+		sizes[i] = mins[i] + extra
+		consumed += sizes[i]
+	}
+	return sizes
+}
+
+func childWeight(b *Box) int {
+	if b.Weight <= 0 {
+		return 1
+	}
+	return b.Weight
+}
+
+func minFor(b *Box, dir Direction) int {
+	if dir == DirectionColumn {
+		return b.MinHeight
+	}
+	return b.MinWidth
+}
@@ -0,0 +1,88 @@
+package tui
+
+import "testing"
+
+func TestArrangeWeightedSplitInNormalWidth(t *testing.T) {
+	root := &Box{
+		Direction: DirectionRow,
+		Children: []*Box{
+			{Name: "left", Weight: 1},
+			{Name: "right", Weight: 3},
+		},
+	}
+
+	dims := root.Arrange(100, 10)
+
+	left, right := dims["left"], dims["right"]
+	if left.X0 != 0 || left.X1 != 25 {
+		t.Errorf("expected left panel to span [0,25), got %+v", left)
+	}
+	if right.X0 != 25 || right.X1 != 100 {
+		t.Errorf("expected right panel to span [25,100), got %+v", right)
+	}
+}
+
+//TestArrangeDoesNotOvershootWhenSiblingMinimumsDontFit checks childSizes on a viewport narrower than
+//the sum of all sibling minimums: clamping each child to its minimum independently of the others
+//would make the running offset overshoot available and leave the last child with a negative-width
+//rectangle.
+func TestArrangeDoesNotOvershootWhenSiblingMinimumsDontFit(t *testing.T) {
+	root := &Box{
+		Direction: DirectionRow,
+		Children: []*Box{
+			{Name: "keys", Weight: 1, MinWidth: 20},
+			{Name: "entries", Weight: 3},
+			{Name: "timeline", Weight: 2, MinWidth: 20},
+		},
+	}
+
+	// sum of minimums (40) exceeds this viewport's width (30)
+	dims := root.Arrange(30, 10)
+
+	total := 0
+	for _, name := range []string{"keys", "entries", "timeline"} {
+		d := dims[name]
+		width := d.X1 - d.X0
+		if width < 0 {
+			t.Errorf("%s: negative width rectangle %+v", name, d)
+		}
+		total += width
+	}
+	if total != 30 {
+		t.Errorf("expected panel widths to sum to the 30-wide viewport, got %d (%+v)", total, dims)
+	}
+}
+
+func TestArrangeHandlesZeroAvailableSpace(t *testing.T) {
+	root := &Box{
+		Direction: DirectionRow,
+		Children: []*Box{
+			{Name: "a", MinWidth: 10},
+			{Name: "b", MinWidth: 10},
+		},
+	}
+
+	dims := root.Arrange(0, 10)
+	for name, d := range dims {
+		if d.X1-d.X0 < 0 {
+			t.Errorf("%s: expected non-negative width on a zero-width viewport, got %+v", name, d)
+		}
+	}
+}
+
+func TestArrangeReflowsOnResize(t *testing.T) {
+	root := &Box{
+		Direction: DirectionColumn,
+		Children: []*Box{
+			{Name: "top", Weight: 1},
+			{Name: "bottom", Weight: 1},
+		},
+	}
+
+	small := root.Arrange(10, 10)
+	large := root.Arrange(10, 100)
+
+	if small["top"] == large["top"] {
+		t.Errorf("expected resizing the viewport to change the computed dimensions")
+	}
+}
@@ -0,0 +1,277 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+
+	"github.com/avade/cicerone/dsl"
+	"github.com/avade/cicerone/viz"
+)
+
+const (
+	viewKeys     = "keys"
+	viewEntries  = "entries"
+	viewTimeline = "timeline"
+	viewStatus   = "status"
+	viewPrompt   = "prompt"
+)
+
+//Browser is an interactive terminal explorer over one or more groupings of the same underlying log
+//data (one per grouping Getter).  It renders a Keys list on the left, the selected group's Entries
+//(in lager format, filterable) in the center, a Timeline sparkline on the right, and a status bar -
+//reflowing the three panels via a Box layout, and the status bar along the bottom row, whenever the
+//terminal is resized.
+type Browser struct {
+	gui *gocui.Gui
+
+	groupings []*dsl.GroupedEntries
+	groupIdx  int
+	current   *dsl.GroupedEntries
+	matcher   dsl.Matcher
+
+	selectedKey       int
+	layout            *Box
+	focusBeforePrompt string
+}
+
+//NewBrowser constructs a Browser cycling (via Tab) over groupings, which must contain at least one
+//*dsl.GroupedEntries.  Call Run to take over the terminal.
+func NewBrowser(groupings ...*dsl.GroupedEntries) *Browser {
+	b := &Browser{
+		groupings: groupings,
+		layout: &Box{
+			Direction: DirectionRow,
+			Children: []*Box{
+				{Name: viewKeys, Weight: 1, MinWidth: 20},
+				{Name: viewEntries, Weight: 3},
+				{Name: viewTimeline, Weight: 2, MinWidth: 20},
+			},
+		},
+	}
+	b.current = groupings[0]
+	return b
+}
+
+//Run opens the terminal UI and blocks until the user quits (Ctrl-C or 'q').
+func (b *Browser) Run() error {
+	g, err := gocui.NewGui(gocui.OutputNormal)
+	if err != nil {
+		return err
+	}
+	defer g.Close()
+
+	b.gui = g
+	g.Cursor = true
+	g.SetManagerFunc(b.layoutFunc)
+
+	if err := b.bindKeys(); err != nil {
+		return err
+	}
+
+	if err := g.MainLoop(); err != nil && err != gocui.ErrQuit {
+		return err
+	}
+	return nil
+}
+
+func (b *Browser) layoutFunc(g *gocui.Gui) error {
+	width, height := g.Size()
+	// reserve the bottom row for the status bar
+	dims := b.layout.Arrange(width, height-1)
+
+	if err := b.renderKeys(g, dims[viewKeys]); err != nil {
+		return err
+	}
+	if err := b.renderEntries(g, dims[viewEntries]); err != nil {
+		return err
+	}
+	if err := b.renderTimeline(g, dims[viewTimeline]); err != nil {
+		return err
+	}
+	return b.renderStatus(g, height-1, width)
+}
+
+func (b *Browser) renderKeys(g *gocui.Gui, d Dimensions) error {
+	v, err := g.SetView(viewKeys, d.X0, d.Y0, d.X1, d.Y1)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Clear()
+	v.Title = "Keys"
+	for i, key := range b.current.Keys {
+		prefix := "  "
+		if i == b.selectedKey {
+			prefix = "> "
+		}
+		fmt.Fprintf(v, "%s%v\n", prefix, key)
+	}
+	return nil
+}
+
+func (b *Browser) renderEntries(g *gocui.Gui, d Dimensions) error {
+	v, err := g.SetView(viewEntries, d.X0, d.Y0, d.X1, d.Y1)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Clear()
+	v.Title = "Entries"
+	v.Wrap = true
+
+	if b.selectedKey >= len(b.current.Keys) {
+		return nil
+	}
+	entries, _ := b.current.Lookup(b.current.Keys[b.selectedKey])
+	if b.matcher != nil {
+		entries = entries.Filter(b.matcher)
+	}
+	return entries.WriteLagerFormatTo(v)
+}
+
+func (b *Browser) renderTimeline(g *gocui.Gui, d Dimensions) error {
+	v, err := g.SetView(viewTimeline, d.X0, d.Y0, d.X1, d.Y1)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Clear()
+	v.Title = "Timeline"
+
+	if b.selectedKey >= len(b.current.Keys) {
+		return nil
+	}
+	entries, _ := b.current.Lookup(b.current.Keys[b.selectedKey])
+	return viz.WriteSparklineTo(v, entries, d.X1-d.X0)
+}
+
+func (b *Browser) renderStatus(g *gocui.Gui, y, width int) error {
+	v, err := g.SetView(viewStatus, 0, y, width, y+1)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	v.Clear()
+	v.Frame = false
+	fmt.Fprintf(v, " %d groups | [/] filter  [g/G] first/last  [tab] toggle grouping  [q] quit",
+		len(b.current.Keys))
+	return nil
+}
+
+func (b *Browser) bindKeys() error {
+	g := b.gui
+	bindings := []struct {
+		key interface{}
+		fn  func(*gocui.Gui, *gocui.View) error
+	}{
+		{gocui.KeyCtrlC, b.quit},
+		{'q', b.quit},
+		{gocui.KeyArrowDown, b.selectNext},
+		{gocui.KeyArrowUp, b.selectPrev},
+		{'g', b.selectFirst},
+		{'G', b.selectLast},
+		{'/', b.promptFilter},
+		{gocui.KeyTab, b.toggleGrouping},
+	}
+	for _, binding := range bindings {
+		if err := g.SetKeybinding("", binding.key, gocui.ModNone, binding.fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Browser) quit(*gocui.Gui, *gocui.View) error {
+	return gocui.ErrQuit
+}
+
+func (b *Browser) selectNext(*gocui.Gui, *gocui.View) error {
+	if b.selectedKey < len(b.current.Keys)-1 {
+		b.selectedKey++
+	}
+	return nil
+}
+
+func (b *Browser) selectPrev(*gocui.Gui, *gocui.View) error {
+	if b.selectedKey > 0 {
+		b.selectedKey--
+	}
+	return nil
+}
+
+func (b *Browser) selectFirst(*gocui.Gui, *gocui.View) error {
+	b.selectedKey = 0
+	return nil
+}
+
+func (b *Browser) selectLast(*gocui.Gui, *gocui.View) error {
+	b.selectedKey = len(b.current.Keys) - 1
+	return nil
+}
+
+//ApplyMatcher filters the active grouping live, re-grouping from it directly (rather than from the
+//previously filtered view) so repeated filters aren't compounded against each other.
+func (b *Browser) ApplyMatcher(matcher dsl.Matcher) {
+	b.matcher = matcher
+	b.current = b.groupings[b.groupIdx]
+	if matcher != nil {
+		b.current = b.current.Filter(matcher)
+	}
+	if b.selectedKey >= len(b.current.Keys) {
+		b.selectedKey = 0
+	}
+}
+
+//toggleGrouping cycles to the next grouping supplied to NewBrowser, re-applying whatever
+//Matcher is currently active so an in-progress filter survives the switch.
+func (b *Browser) toggleGrouping(*gocui.Gui, *gocui.View) error {
+	b.groupIdx = (b.groupIdx + 1) % len(b.groupings)
+	b.ApplyMatcher(b.matcher)
+	return nil
+}
+
+//promptFilter opens a single-line editable prompt view just above the status bar and gives it the
+//keyboard focus; Enter commits the typed text as a live Matcher (via ApplyMatcher), Escape cancels
+//without changing the current filter.  Either way focus returns to whichever view was active before
+//the prompt opened, not to gocui's unregistered "" view.
+func (b *Browser) promptFilter(g *gocui.Gui, v *gocui.View) error {
+	if v != nil {
+		b.focusBeforePrompt = v.Name()
+	} else {
+		b.focusBeforePrompt = viewKeys
+	}
+
+	width, height := g.Size()
+	prompt, err := g.SetView(viewPrompt, 0, height-2, width, height-1)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	prompt.Clear()
+	prompt.Frame = false
+	prompt.Editable = true
+	prompt.Title = "filter"
+	fmt.Fprint(prompt, "/")
+
+	if err := g.SetKeybinding(viewPrompt, gocui.KeyEnter, gocui.ModNone, b.commitFilter); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(viewPrompt, gocui.KeyEsc, gocui.ModNone, b.cancelFilter); err != nil {
+		return err
+	}
+
+	_, err = g.SetCurrentView(viewPrompt)
+	return err
+}
+
+func (b *Browser) commitFilter(g *gocui.Gui, v *gocui.View) error {
+	text := strings.TrimPrefix(strings.TrimSpace(v.Buffer()), "/")
+	b.ApplyMatcher(dsl.MatchMessage(text))
+
+	g.DeleteView(viewPrompt)
+	_, err := g.SetCurrentView(b.focusBeforePrompt)
+	return err
+}
+
+func (b *Browser) cancelFilter(g *gocui.Gui, v *gocui.View) error {
+	g.DeleteView(viewPrompt)
+	_, err := g.SetCurrentView(b.focusBeforePrompt)
+	return err
+}
@@ -0,0 +1,195 @@
+package viz
+
+import (
+	"image/color"
+	"math"
+)
+
+//lchColor is a color expressed in CIE LCh(ab): perceptually uniform Lightness, Chroma, and hue
+//angle.  Interpolating in this space (rather than RGB) keeps equally-spaced samples equally
+//distinguishable to the eye, which is the whole point of generating a palette instead of hardcoding one.
+type lchColor struct {
+	L, C, h float64
+}
+
+func lerpLCh(points []lchColor, t float64) lchColor {
+	if len(points) == 1 {
+		return points[0]
+	}
+	span := t * float64(len(points)-1)
+	i := int(span)
+	if i >= len(points)-1 {
+		return points[len(points)-1]
+	}
+	frac := span - float64(i)
+	a, b := points[i], points[i+1]
+	return lchColor{
+		L: a.L + (b.L-a.L)*frac,
+		C: a.C + (b.C-a.C)*frac,
+		h: a.h + (b.h-a.h)*frac,
+	}
+}
+
+func (c lchColor) toRGB() color.RGBA {
+	// LCh -> Lab
+	hRad := c.h * math.Pi / 180
+	labA := c.C * math.Cos(hRad)
+	labB := c.C * math.Sin(hRad)
+
+	// Lab -> XYZ (D65 reference white)
+	fy := (c.L + 16) / 116
+	fx := fy + labA/500
+	fz := fy - labB/200
+
+	finv := func(f float64) float64 {
+		if f3 := f * f * f; f3 > 0.008856 {
+			return f3
+		}
+		return (f - 16.0/116) / 7.787
+	}
+
+	x := finv(fx) * 95.047
+	y := finv(fy) * 100.000
+	z := finv(fz) * 108.883
+
+	// XYZ -> linear sRGB
+	x, y, z = x/100, y/100, z/100
+	r := x*3.2406 + y*-1.5372 + z*-0.4986
+	g := x*-0.9689 + y*1.8758 + z*0.0415
+	b := x*0.0557 + y*-0.2040 + z*1.0570
+
+	gamma := func(v float64) float64 {
+		if v <= 0.0031308 {
+			return 12.92 * v
+		}
+		return 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+
+	return color.RGBA{
+		R: clampByte(gamma(r)),
+		G: clampByte(gamma(g)),
+		B: clampByte(gamma(b)),
+		A: 255,
+	}
+}
+
+func clampByte(v float64) uint8 {
+	if v <= 0 {
+		return 0
+	}
+	if v >= 1 {
+		return 255
+	}
+	return uint8(v*255 + 0.5)
+}
+
+func rgbToHSL(c color.RGBA) (h, s, l float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	l = (max + min) / 2
+
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h, s, l
+}
+
+func hslToRGB(h, s, l float64) color.RGBA {
+	if s == 0 {
+		v := clampByte(l)
+		return color.RGBA{v, v, v, 255}
+	}
+
+	hueToRGB := func(p, q, t float64) float64 {
+		if t < 0 {
+			t++
+		}
+		if t > 1 {
+			t--
+		}
+		switch {
+		case t < 1.0/6:
+			return p + (q-p)*6*t
+		case t < 1.0/2:
+			return q
+		case t < 2.0/3:
+			return p + (q-p)*(2.0/3-t)*6
+		default:
+			return p
+		}
+	}
+
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	hNorm := h / 360
+
+	return color.RGBA{
+		R: clampByte(hueToRGB(p, q, hNorm+1.0/3)),
+		G: clampByte(hueToRGB(p, q, hNorm)),
+		B: clampByte(hueToRGB(p, q, hNorm-1.0/3)),
+		A: 255,
+	}
+}
+
+//viridisControlPoints are evenly-spaced LCh samples approximating the Viridis colormap (dark
+//purple -> teal -> yellow, monotonically increasing lightness).
+var viridisControlPoints = []lchColor{
+	{L: 12, C: 45, h: 295},
+	{L: 30, C: 45, h: 280},
+	{L: 45, C: 40, h: 220},
+	{L: 60, C: 45, h: 170},
+	{L: 75, C: 55, h: 130},
+	{L: 90, C: 70, h: 95},
+}
+
+//turboControlPoints are evenly-spaced LCh samples approximating the Turbo colormap (blue -> green
+//-> yellow -> red), trading Viridis's monotonic lightness for extra contrast between midtones.
+var turboControlPoints = []lchColor{
+	{L: 25, C: 55, h: 280},
+	{L: 45, C: 60, h: 220},
+	{L: 65, C: 60, h: 150},
+	{L: 80, C: 65, h: 95},
+	{L: 70, C: 70, h: 45},
+	{L: 50, C: 70, h: 20},
+}
+
+//category20 is D3/matplotlib's 20-color categorical palette.
+var category20 = []color.RGBA{
+	{31, 119, 180, 255}, {174, 199, 232, 255}, {255, 127, 14, 255}, {255, 187, 120, 255},
+	{44, 160, 44, 255}, {152, 223, 138, 255}, {214, 39, 40, 255}, {255, 152, 150, 255},
+	{148, 103, 189, 255}, {197, 176, 213, 255}, {140, 86, 75, 255}, {196, 156, 148, 255},
+	{227, 119, 194, 255}, {247, 182, 210, 255}, {127, 127, 127, 255}, {199, 199, 199, 255},
+	{188, 189, 34, 255}, {219, 219, 141, 255}, {23, 190, 207, 255}, {158, 218, 229, 255},
+}
+
+//colorblindSafe is Okabe & Ito's 8-color colorblind-safe palette.
+var colorblindSafe = []color.RGBA{
+	{0, 0, 0, 255}, {230, 159, 0, 255}, {86, 180, 233, 255}, {0, 158, 115, 255},
+	{240, 228, 66, 255}, {0, 114, 178, 255}, {213, 94, 0, 255}, {204, 121, 167, 255},
+}
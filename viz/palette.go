@@ -0,0 +1,124 @@
+package viz
+
+import (
+	"image/color"
+	"math"
+)
+
+//Preset names a built-in color scheme for NewPalette.
+type Preset int
+
+const (
+	//PresetViridis samples the Viridis colormap: perceptually uniform, monotonically increasing
+	//lightness, legible in both color and grayscale.
+	PresetViridis Preset = iota
+	//PresetTurbo samples Google's Turbo colormap: higher contrast than Viridis at the cost of a
+	//non-monotonic lightness ramp.
+	PresetTurbo
+	//PresetCategory20 cycles the 20-color categorical palette popularized by D3/matplotlib,
+	//extended by further rotating its hue when more than 20 colors are requested.
+	PresetCategory20
+	//PresetColorblindSafe cycles Okabe & Ito's 8-color colorblind-safe palette, extended the same
+	//way as PresetCategory20 once exhausted.
+	PresetColorblindSafe
+)
+
+//PaletteOptions configures NewPalette.
+type PaletteOptions struct {
+	Preset Preset
+}
+
+//ColorPalette hands out distinguishable colors for an arbitrary, growing set of group keys.
+//Colors are generated once (by sampling N points from the chosen Preset) and then handed out on
+//demand via Color, so two calls with the same key always get the same color and callers don't need
+//to know the total number of groups up front.
+type ColorPalette struct {
+	colors []color.RGBA
+	byKey  map[interface{}]color.RGBA
+	opts   PaletteOptions
+}
+
+//NewPalette generates n distinguishable colors by sampling opts.Preset.  For the gradient-based
+//presets (Viridis, Turbo) the colors are sampled evenly along the colormap in a perceptually uniform
+//(CIE LCh) space so that adjacent colors remain distinguishable even as n grows into the hundreds;
+//for the categorical presets (Category20, Colorblind-safe) the fixed swatch is cycled, rotating hue
+//by a golden-angle step once it runs out so repeats still differ from the originals.  n is clamped
+//to at least 1, so starting from NewPalette(0, opts) and growing purely through Color is safe.
+func NewPalette(n int, opts PaletteOptions) ColorPalette {
+	if n < 1 {
+		// Color grows the palette by indexing colors[len(byKey)%len(colors)], so it needs at
+		// least one color to hand out even when the caller starts from n=0 and grows via Color.
+		n = 1
+	}
+
+	p := ColorPalette{
+		byKey: map[interface{}]color.RGBA{},
+		opts:  opts,
+	}
+
+	switch opts.Preset {
+	case PresetCategory20:
+		p.colors = cycleSwatch(category20, n)
+	case PresetColorblindSafe:
+		p.colors = cycleSwatch(colorblindSafe, n)
+	case PresetTurbo:
+		p.colors = sampleGradient(turboControlPoints, n)
+	default:
+		p.colors = sampleGradient(viridisControlPoints, n)
+	}
+
+	return p
+}
+
+//Colors returns the full, ordered slice of generated colors.
+func (p ColorPalette) Colors() []color.RGBA {
+	return p.colors
+}
+
+//Color returns the color assigned to key, generating and memoizing a new assignment (in order) the
+//first time key is seen.  Once the palette's generated colors are exhausted, later keys wrap around
+//rather than erroring, so long-running callers with unbounded group counts keep working.
+func (p ColorPalette) Color(key interface{}) color.RGBA {
+	if c, ok := p.byKey[key]; ok {
+		return c
+	}
+	c := p.colors[len(p.byKey)%len(p.colors)]
+	p.byKey[key] = c
+	return c
+}
+
+func cycleSwatch(swatch []color.RGBA, n int) []color.RGBA {
+	out := make([]color.RGBA, n)
+	for i := range out {
+		c := swatch[i%len(swatch)]
+		rotation := i / len(swatch)
+		if rotation == 0 {
+			out[i] = c
+			continue
+		}
+		h, s, l := rgbToHSL(c)
+		h = math.Mod(h+float64(rotation)*137.508, 360) // golden-angle hue rotation
+		out[i] = hslToRGB(h, s, l)
+	}
+	return out
+}
+
+func sampleGradient(controlPoints []lchColor, n int) []color.RGBA {
+	out := make([]color.RGBA, n)
+	if n == 1 {
+		out[0] = controlPoints[0].toRGB()
+		return out
+	}
+	for i := 0; i < n; i++ {
+		t := float64(i) / float64(n-1)
+		out[i] = lerpLCh(controlPoints, t).toRGB()
+	}
+	return out
+}
+
+//OrderedColors is a deprecated 28-color palette, the same length as the list the package used to
+//hardcode.  Its colors are now generated from PresetCategory20, not the old hardcoded values.
+//
+//Deprecated: use NewPalette(n, PaletteOptions{Preset: PresetCategory20}) instead, which generates
+//exactly as many colors as you need instead of silently repeating past 14 groups.
+var OrderedColors = NewPalette(28, PaletteOptions{Preset: PresetCategory20}).Colors()
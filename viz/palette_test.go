@@ -0,0 +1,80 @@
+package viz
+
+import "testing"
+
+func TestNewPaletteGeneratesRequestedCount(t *testing.T) {
+	for _, preset := range []Preset{PresetViridis, PresetTurbo, PresetCategory20, PresetColorblindSafe} {
+		p := NewPalette(50, PaletteOptions{Preset: preset})
+		if len(p.Colors()) != 50 {
+			t.Errorf("preset %v: expected 50 colors, got %d", preset, len(p.Colors()))
+		}
+	}
+}
+
+func TestColorPaletteAssignsStableColorsPerKey(t *testing.T) {
+	p := NewPalette(3, PaletteOptions{Preset: PresetCategory20})
+
+	first := p.Color("auctioneer")
+	second := p.Color("rep")
+	again := p.Color("auctioneer")
+
+	if first != again {
+		t.Errorf("expected repeated calls for the same key to return the same color")
+	}
+	if first == second {
+		t.Errorf("expected distinct keys to receive distinct colors")
+	}
+}
+
+func TestNewPaletteZeroOrNegativeCountDoesNotPanic(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		p := NewPalette(n, PaletteOptions{Preset: PresetCategory20})
+		first := p.Color("a")
+		second := p.Color("b")
+		if first != second {
+			t.Errorf("n=%d: expected a clamped 1-color palette to wrap every key to the same color", n)
+		}
+	}
+}
+
+func TestColorPaletteWrapsPastGeneratedColors(t *testing.T) {
+	p := NewPalette(1, PaletteOptions{Preset: PresetCategory20})
+	first := p.Color("a")
+	second := p.Color("b")
+	if first != second {
+		t.Errorf("expected colors to wrap around once the 1-color palette is exhausted")
+	}
+}
+
+func TestOrderedColorsIsA28ColorPalette(t *testing.T) {
+	if len(OrderedColors) != 28 {
+		t.Errorf("expected the deprecated OrderedColors alias to keep its historical length of 28, got %d", len(OrderedColors))
+	}
+}
+
+func TestLChToRGBIsOpaque(t *testing.T) {
+	for _, c := range viridisControlPoints {
+		rgb := c.toRGB()
+		if rgb.A != 255 {
+			t.Errorf("expected fully opaque output, got alpha %d", rgb.A)
+		}
+	}
+}
+
+func TestHSLRGBRoundTrip(t *testing.T) {
+	for _, want := range category20 {
+		h, s, l := rgbToHSL(want)
+		got := hslToRGB(h, s, l)
+		if !closeByte(want.R, got.R) || !closeByte(want.G, got.G) || !closeByte(want.B, got.B) {
+			t.Errorf("round trip mismatch: %+v -> hsl(%.1f,%.2f,%.2f) -> %+v", want, h, s, l, got)
+		}
+	}
+}
+
+func closeByte(a, b uint8) bool {
+	diff := int(a) - int(b)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= 2
+}
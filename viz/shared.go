@@ -2,7 +2,6 @@ package viz
 
 import (
 	"fmt"
-	"image/color"
 
 	"code.google.com/p/plotinum/plot"
 	"code.google.com/p/plotinum/vg"
@@ -19,37 +18,6 @@ func init() {
 	}
 }
 
-var OrderedColors = []color.RGBA{
-	{0, 0, 0, 255},
-	{255, 0, 0, 255},
-	{0, 200, 0, 255},
-	{0, 0, 255, 255},
-	{125, 0, 0, 255},
-	{0, 125, 0, 255},
-	{0, 0, 125, 255},
-	{125, 125, 0, 255},
-	{125, 0, 125, 255},
-	{0, 125, 125, 255},
-	{125, 125, 125, 255},
-	{200, 200, 200, 255},
-	{255, 125, 0, 255},
-	{0, 125, 255, 255},
-	{0, 0, 0, 255},
-	{255, 0, 0, 255},
-	{0, 200, 0, 255},
-	{0, 0, 255, 255},
-	{125, 0, 0, 255},
-	{0, 125, 0, 255},
-	{0, 0, 125, 255},
-	{125, 125, 0, 255},
-	{125, 0, 125, 255},
-	{0, 125, 125, 255},
-	{125, 125, 125, 255},
-	{200, 200, 200, 255},
-	{255, 125, 0, 255},
-	{0, 125, 255, 255},
-}
-
 func pathRectangle(top vg.Length, right vg.Length, bottom vg.Length, left vg.Length) vg.Path {
 	p := vg.Path{}
 	p.Move(left, top)
@@ -0,0 +1,66 @@
+package viz
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/avade/cicerone/dsl"
+)
+
+//sparkTicks are the block characters used to render bucket heights, lowest to highest.
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+//WriteSparklineTo renders entries as a single-line Unicode sparkline (one character per bucket,
+//height proportional to the number of entries whose Timestamp falls in that bucket) to w.  entries
+//need not be sorted.  width controls how many buckets the time range is split into; a width <= 0,
+//or no entries, writes just an empty line.
+func WriteSparklineTo(w io.Writer, entries dsl.Entries, width int) error {
+	if width <= 0 || len(entries) == 0 {
+		_, err := fmt.Fprintln(w)
+		return err
+	}
+
+	min, max := entries[0].Timestamp, entries[0].Timestamp
+	for _, entry := range entries {
+		if entry.Timestamp.Before(min) {
+			min = entry.Timestamp
+		}
+		if entry.Timestamp.After(max) {
+			max = entry.Timestamp
+		}
+	}
+
+	buckets := make([]int, width)
+	span := max.Sub(min)
+	for _, entry := range entries {
+		idx := 0
+		if span > 0 {
+			idx = int(entry.Timestamp.Sub(min) * time.Duration(width) / span)
+			if idx >= width {
+				idx = width - 1
+			}
+		}
+		buckets[idx]++
+	}
+
+	peak := 0
+	for _, count := range buckets {
+		if count > peak {
+			peak = count
+		}
+	}
+
+	line := make([]rune, width)
+	for i, count := range buckets {
+		if peak == 0 {
+			line[i] = sparkTicks[0]
+			continue
+		}
+		tick := count * (len(sparkTicks) - 1) / peak
+		line[i] = sparkTicks[tick]
+	}
+
+	_, err := fmt.Fprintln(w, string(line))
+	return err
+}